@@ -0,0 +1,55 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ServeAdminMenu returns the currently-loaded menu as JSON, so operators can
+// confirm what's live before/after editing menu.yaml.
+func (h *Handler) ServeAdminMenu(c *gin.Context) {
+	c.JSON(http.StatusOK, h.menu.Get())
+}
+
+// HandleReloadMenu re-reads menu.yaml from disk. The menu is only swapped in
+// if the file parses and validates, so a bad edit can't take down ordering.
+func (h *Handler) HandleReloadMenu(c *gin.Context) {
+	if err := h.menu.Reload(); err != nil {
+		slog.Error("Failed to reload menu", "error", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.menu.Get())
+}
+
+type createAPIKeyForm struct {
+	Label string `json:"label" binding:"required"`
+}
+
+// HandleCreateAPIKey provisions a new API key for a third-party integration
+// and returns its plaintext once. The key is stored hashed, so this is the
+// only response that will ever contain it — operators must copy it down
+// immediately, the same as any other credential issued at creation time.
+func (h *Handler) HandleCreateAPIKey(c *gin.Context) {
+	var form createAPIKeyForm
+	if err := c.ShouldBindJSON(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	plaintextKey, apiKey, err := h.apiKeys.Create(form.Label)
+	if err != nil {
+		slog.Error("Failed to create API key", "error", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "something went wrong"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"id":    apiKey.ID,
+		"label": apiKey.Label,
+		"key":   plaintextKey,
+	})
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"errors"
+	"go-pizza-tracker/internal/models"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIOrderItem is one pizza line in the nested JSON order payload, as
+// opposed to the parallel-arrays form the HTML order form submits.
+type APIOrderItem struct {
+	Size                string   `json:"size" binding:"required,valid_pizza_size"`
+	Pizza               string   `json:"pizza" binding:"required,valid_pizza_type"`
+	Crust               string   `json:"crust"`
+	Instructions        string   `json:"instructions"`
+	Toppings            []string `json:"toppings"`
+	DietaryRequirements []string `json:"dietaryRequirements"`
+	Allergies           []string `json:"allergies"`
+}
+
+type NewOrderAPIRequest struct {
+	Name    string         `json:"name" binding:"required,min=2,max=100"`
+	Phone   string         `json:"phone" binding:"required,min=9,max=20"`
+	Address string         `json:"address" binding:"required,min=5,max=200"`
+	Items   []APIOrderItem `json:"items" binding:"required,min=1,dive"`
+}
+
+type OrderStatusAPIRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+// apiError writes the REST API's structured error shape:
+// {"error": {"code", "message", "fields"}}.
+func apiError(c *gin.Context, status int, code, message string, fields map[string]string) {
+	c.JSON(status, gin.H{
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+			"fields":  fields,
+		},
+	})
+}
+
+// APIKeyRequired protects mutating /api/v1 routes with a per-integration key
+// passed in the X-API-Key header.
+func (h *Handler) APIKeyRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader("X-API-Key")
+		if key == "" {
+			apiError(c, http.StatusUnauthorized, "missing_api_key", "X-API-Key header is required", nil)
+			c.Abort()
+			return
+		}
+
+		valid, err := h.apiKeys.Validate(key)
+		if err != nil {
+			slog.Error("Failed to validate API key", "error", err)
+			apiError(c, http.StatusInternalServerError, "internal_error", "something went wrong", nil)
+			c.Abort()
+			return
+		}
+		if !valid {
+			apiError(c, http.StatusUnauthorized, "invalid_api_key", "API key is invalid or revoked", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+func (h *Handler) HandleCreateOrderAPI(c *gin.Context) {
+	var req NewOrderAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_request", err.Error(), nil)
+		return
+	}
+
+	orderItems := make([]models.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		crust := item.Crust
+		if crust == "" {
+			crust = "Regular"
+		}
+
+		orderItems[i] = models.OrderItem{
+			Size:         item.Size,
+			Pizza:        item.Pizza,
+			Crust:        crust,
+			Instructions: item.Instructions,
+		}
+
+		for _, topping := range item.Toppings {
+			orderItems[i].Toppings = append(orderItems[i].Toppings, models.OrderItemTopping{Topping: topping})
+		}
+		for _, dietary := range item.DietaryRequirements {
+			orderItems[i].DietaryRequirement = append(orderItems[i].DietaryRequirement, models.OrderItemDietaryRequirement{DietaryRequirement: dietary})
+		}
+		for _, allergy := range item.Allergies {
+			orderItems[i].Allergies = append(orderItems[i].Allergies, models.OrderItemAllergy{Allergy: allergy})
+		}
+	}
+
+	order := models.Order{
+		CustomerName: req.Name,
+		Phone:        req.Phone,
+		Address:      req.Address,
+		Status:       models.OrderStatusPlaced,
+		Items:        orderItems,
+	}
+
+	quote := h.priceBook.Quote(pricingItems(order.Items))
+	for i := range order.Items {
+		order.Items[i].Subtotal = quote.Items[i].Subtotal
+	}
+	order.Subtotal = quote.Subtotal
+	order.Tax = quote.Tax
+	order.Total = order.Subtotal + order.Tax
+
+	if err := h.orders.CreateOrder(c.Request.Context(), &order); err != nil {
+		slog.Error("Failed to create order via API", "error", err)
+		apiError(c, http.StatusInternalServerError, "internal_error", "something went wrong", nil)
+		return
+	}
+
+	c.JSON(http.StatusCreated, order)
+}
+
+func (h *Handler) HandleGetOrderAPI(c *gin.Context) {
+	order, err := h.orders.GetOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		apiError(c, http.StatusNotFound, "order_not_found", "order not found", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+func (h *Handler) HandleGetOrderStatusAPI(c *gin.Context) {
+	order, err := h.orders.GetOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		apiError(c, http.StatusNotFound, "order_not_found", "order not found", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": order.Status})
+}
+
+func (h *Handler) HandlePatchOrderStatusAPI(c *gin.Context) {
+	var req OrderStatusAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_request", err.Error(), nil)
+		return
+	}
+
+	orderID := c.Param("id")
+	if err := h.orders.SetStatus(c.Request.Context(), orderID, req.Status, nil); err != nil {
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			apiError(c, http.StatusConflict, "invalid_transition", "cannot skip order statuses", nil)
+			return
+		}
+		slog.Error("Failed to update order status via API", "error", err, "orderID", orderID)
+		apiError(c, http.StatusInternalServerError, "internal_error", "something went wrong", nil)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": req.Status})
+}
+
+// MenuResponse is the full menu as one JSON document, for third-party
+// integrations building their own ordering UI.
+type MenuResponse struct {
+	PizzaTypes           []string            `json:"pizzaTypes"`
+	PizzaSizes           []string            `json:"pizzaSizes"`
+	PizzaCrust           []string            `json:"pizzaCrust"`
+	ToppingCategories    map[string][]string `json:"toppingCategories"`
+	PizzaDefaultToppings map[string][]string `json:"pizzaDefaultToppings"`
+}
+
+func (h *Handler) HandleGetMenuAPI(c *gin.Context) {
+	m := h.menu.Get()
+	c.JSON(http.StatusOK, MenuResponse{
+		PizzaTypes:           m.PizzaTypes,
+		PizzaSizes:           m.PizzaSizes,
+		PizzaCrust:           m.PizzaCrust,
+		ToppingCategories:    m.ToppingCategories,
+		PizzaDefaultToppings: m.PizzaDefaultToppings,
+	})
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"go-pizza-tracker/internal/models"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+)
+
+// sessionUserIDKey is the key the logged-in user's ID is stored under in the
+// signed session cookie.
+const sessionUserIDKey = "userID"
+
+// loyaltyDiscountRate is applied to the subtotal of an order placed by a
+// logged-in user as a thank-you for having an account.
+const loyaltyDiscountRate = 0.05
+
+type RegisterForm struct {
+	Name     string `form:"name" binding:"required,min=2,max=100"`
+	Email    string `form:"email" binding:"required,email"`
+	Password string `form:"password" binding:"required,min=8,max=72"`
+	Phone    string `form:"phone" binding:"required,min=9,max=20"`
+	Address  string `form:"address" binding:"required,min=5,max=200"`
+}
+
+type LoginForm struct {
+	Email    string `form:"email" binding:"required,email"`
+	Password string `form:"password" binding:"required"`
+}
+
+type AccountData struct {
+	Title string
+	User  models.User
+}
+
+type AccountOrdersData struct {
+	Title  string
+	User   models.User
+	Orders []models.Order
+}
+
+func (h *Handler) ServeRegister(c *gin.Context) {
+	c.HTML(http.StatusOK, "register.tmpl", gin.H{"Title": "Create Account"})
+}
+
+func (h *Handler) HandleRegister(c *gin.Context) {
+	var form RegisterForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user := models.User{
+		Name:    form.Name,
+		Email:   form.Email,
+		Phone:   form.Phone,
+		Address: form.Address,
+	}
+
+	if err := h.users.Register(&user, form.Password); err != nil {
+		if errors.Is(err, models.ErrDuplicateEmail) {
+			c.JSON(http.StatusConflict, gin.H{"error": "an account with that email already exists"})
+			return
+		}
+		slog.Error("Failed to register user", "error", err)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	h.logInUser(c, &user)
+	c.Redirect(http.StatusSeeOther, "/account")
+}
+
+func (h *Handler) ServeLogin(c *gin.Context) {
+	c.HTML(http.StatusOK, "login.tmpl", gin.H{"Title": "Log In"})
+}
+
+func (h *Handler) HandleLogin(c *gin.Context) {
+	var form LoginForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.users.Authenticate(form.Email, form.Password)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidCredentials) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid email or password"})
+			return
+		}
+		slog.Error("Failed to authenticate user", "error", err)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	h.logInUser(c, user)
+	c.Redirect(http.StatusSeeOther, "/account")
+}
+
+func (h *Handler) HandleLogout(c *gin.Context) {
+	session := sessions.Default(c)
+	session.Clear()
+	if err := session.Save(); err != nil {
+		slog.Error("Failed to clear session", "error", err)
+	}
+	c.Redirect(http.StatusSeeOther, "/login")
+}
+
+func (h *Handler) ServeAccount(c *gin.Context) {
+	user := currentUser(c)
+
+	c.HTML(http.StatusOK, "account.tmpl", AccountData{
+		Title: "My Account",
+		User:  *user,
+	})
+}
+
+func (h *Handler) ServeAccountOrders(c *gin.Context) {
+	user := currentUser(c)
+
+	orders, err := h.users.GetOrders(user.ID)
+	if err != nil {
+		slog.Error("Failed to load order history", "error", err, "userID", user.ID)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	c.HTML(http.StatusOK, "account_orders.tmpl", AccountOrdersData{
+		Title:  "My Orders",
+		User:   *user,
+		Orders: orders,
+	})
+}
+
+// logInUser starts a signed-cookie session for the given user.
+func (h *Handler) logInUser(c *gin.Context, user *models.User) {
+	session := sessions.Default(c)
+	session.Set(sessionUserIDKey, user.ID)
+	if err := session.Save(); err != nil {
+		slog.Error("Failed to save session", "error", err)
+	}
+}
+
+// AuthRequired gates routes behind a logged-in session, loading the user and
+// stashing it on the gin context under "user" for downstream handlers.
+func (h *Handler) AuthRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		id, ok := session.Get(sessionUserIDKey).(string)
+		if !ok || id == "" {
+			c.Redirect(http.StatusSeeOther, "/login")
+			c.Abort()
+			return
+		}
+
+		user, err := h.users.GetByID(id)
+		if err != nil {
+			session.Clear()
+			session.Save()
+			c.Redirect(http.StatusSeeOther, "/login")
+			c.Abort()
+			return
+		}
+
+		c.Set("user", user)
+		c.Next()
+	}
+}
+
+// OptionalAuth loads the logged-in user (if any) onto the context without
+// requiring one, so public routes like guest checkout can still personalise
+// the experience for signed-in customers.
+func (h *Handler) OptionalAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		session := sessions.Default(c)
+		id, ok := session.Get(sessionUserIDKey).(string)
+		if !ok || id == "" {
+			c.Next()
+			return
+		}
+
+		user, err := h.users.GetByID(id)
+		if err == nil {
+			c.Set("user", user)
+		}
+		c.Next()
+	}
+}
+
+// AdminRequired gates staff-only routes. It must run after AuthRequired.
+func (h *Handler) AdminRequired() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		user := currentUser(c)
+		if user == nil || !user.IsAdmin {
+			c.String(http.StatusForbidden, "Forbidden")
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// currentUser returns the logged-in user stashed on the context by
+// AuthRequired or OptionalAuth, or nil if no user is logged in.
+func currentUser(c *gin.Context) *models.User {
+	v, ok := c.Get("user")
+	if !ok {
+		return nil
+	}
+	user, ok := v.(*models.User)
+	if !ok {
+		return nil
+	}
+	return user
+}
@@ -2,10 +2,7 @@ package main
 
 import (
 	"go-pizza-tracker/internal/models"
-	"log/slog"
 	"net/http"
-	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,8 +13,6 @@ type CustomerData struct {
 	Statuses []string
 }
 
-// in future i want to change so that a customer can login and then input customer data, this allows a discount and also helps with learnign authetnication
-// so in future the form to create will include customer name, phone, address, and then the items which is the pizza/s that they will order
 type OrderFormData struct {
 	PizzaTypes           []string
 	PizzaSizes           []string
@@ -27,6 +22,9 @@ type OrderFormData struct {
 	ToppingCategories    map[string][]string
 	DietaryRequirements  []string
 	Allergies            []string
+	// User is set when the customer is logged in, so the form can prefill
+	// their saved name/phone/address.
+	User *models.User
 }
 
 type OrderRequest struct {
@@ -43,15 +41,17 @@ type OrderRequest struct {
 }
 
 func (h *Handler) ServeNewOrder(c *gin.Context) {
+	m := h.menu.Get()
 	c.HTML(http.StatusOK, "order.tmpl", OrderFormData{
-		PizzaTypes:           models.PizzaTypes,
-		PizzaSizes:           models.PizzaSizes,
-		PizzaCrust:           models.PizzaCrust,
-		PizzaCheese:          models.Cheeses,
-		PizzaDefaultToppings: models.PizzaDefaultToppings,
-		ToppingCategories:    models.ToppingCategories,
-		DietaryRequirements:  models.DietaryRequirements,
-		Allergies:            models.Allergies,
+		PizzaTypes:           m.PizzaTypes,
+		PizzaSizes:           m.PizzaSizes,
+		PizzaCrust:           m.PizzaCrust,
+		PizzaCheese:          m.Cheeses,
+		PizzaDefaultToppings: m.PizzaDefaultToppings,
+		ToppingCategories:    m.ToppingCategories,
+		DietaryRequirements:  m.DietaryRequirements,
+		Allergies:            m.Allergies,
+		User:                 currentUser(c),
 	})
 }
 
@@ -65,17 +65,15 @@ func (h *Handler) HandleNewOrder(c *gin.Context) {
 	// Parse toppings per pizza: format is "pizzaIndex:topping"
 	toppingsMap := make(map[int][]string)
 	for _, t := range form.Toppings {
-		parts := strings.SplitN(t, ":", 2)
-		if len(parts) != 2 {
+		index, topping, ok := parseIndexedTopping(t)
+		if !ok {
 			continue
 		}
-		index, err := strconv.Atoi(parts[0])
-		if err != nil {
-			continue
-		}
-		toppingsMap[index] = append(toppingsMap[index], parts[1])
+		toppingsMap[index] = append(toppingsMap[index], topping)
 	}
 
+	defaultToppingsByPizza := h.menu.Get().PizzaDefaultToppings
+
 	orderItems := make([]models.OrderItem, len(form.Sizes))
 	for i := range orderItems {
 		crust := "Regular"
@@ -100,11 +98,11 @@ func (h *Handler) HandleNewOrder(c *gin.Context) {
 
 		// If no toppings selected (customize not opened), use defaults
 		if len(pizzaToppings) == 0 {
-			pizzaToppings = models.PizzaDefaultToppings[form.PizzaTypes[i]]
+			pizzaToppings = defaultToppingsByPizza[form.PizzaTypes[i]]
 		}
 
 		// Determine which are default vs extra
-		defaultToppings := models.PizzaDefaultToppings[form.PizzaTypes[i]]
+		defaultToppings := defaultToppingsByPizza[form.PizzaTypes[i]]
 
 		for _, topping := range pizzaToppings {
 			isExtra := true
@@ -144,13 +142,33 @@ func (h *Handler) HandleNewOrder(c *gin.Context) {
 		Items:        orderItems,
 	}
 
-	if err := h.orders.CreateOrder(&order); err != nil {
-		slog.Error("Failed to create order", "Error", err)
+	// Logged-in customers get their order linked to their account and a
+	// loyalty discount applied as a thank-you for having an account.
+	if user := currentUser(c); user != nil {
+		order.UserID = &user.ID
+		order.LoyaltyDiscount = loyaltyDiscountRate
+	}
+
+	// Apply the loyalty discount uniformly to items, subtotal and tax so the
+	// itemised receipt in customer.tmpl reconciles against the order total.
+	quote := h.priceBook.Quote(pricingItems(order.Items))
+	discountFactor := 1 - order.LoyaltyDiscount
+	for i := range order.Items {
+		order.Items[i].Subtotal = quote.Items[i].Subtotal * discountFactor
+	}
+	order.Subtotal = quote.Subtotal * discountFactor
+	order.Tax = quote.Tax * discountFactor
+	order.Total = order.Subtotal + order.Tax
+
+	logger := loggerFromContext(c)
+
+	if err := h.orders.CreateOrder(c.Request.Context(), &order); err != nil {
+		logger.Error("Failed to create order", "error", err)
 		c.String(http.StatusInternalServerError, "Something went wrong")
 		return
 	}
 
-	slog.Info("Order Created", "orderID", order.ID, "Customer", order.CustomerName)
+	logger.Info("Order created", "orderID", order.ID, "customer", order.CustomerName)
 	c.Redirect(http.StatusSeeOther, "/customer/"+order.ID)
 }
 
@@ -161,7 +179,7 @@ func (h *Handler) serveCustomer(c *gin.Context) {
 		c.String(http.StatusBadRequest, "Order ID is required")
 	}
 
-	order, err := h.orders.GetOrder(orderID)
+	order, err := h.orders.GetOrder(c.Request.Context(), orderID)
 	if err != nil {
 		c.String(http.StatusNotFound, "Order Not Found")
 		return
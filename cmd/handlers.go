@@ -1,13 +1,31 @@
 package main
 
-import "go-pizza-tracker/internal/models"
+import (
+	"go-pizza-tracker/internal/menu"
+	"go-pizza-tracker/internal/models"
+	"go-pizza-tracker/internal/pricing"
+)
 
 type Handler struct {
-	orders *models.OrderModel
+	orders    *models.OrderModel
+	users     *models.UserModel
+	apiKeys   *models.APIKeyModel
+	statusHub *StatusHub
+	priceBook *pricing.PriceBook
+	menu      *menu.Store
 }
 
-func NewHandler(dbModel *models.DBModel) *Handler {
-	return &Handler{
-		orders: &dbModel.Order,
+func NewHandler(dbModel *models.DBModel, priceBook *pricing.PriceBook, menuStore *menu.Store) *Handler {
+	h := &Handler{
+		orders:    &dbModel.Order,
+		users:     &dbModel.User,
+		apiKeys:   &dbModel.APIKeys,
+		statusHub: NewStatusHub(),
+		priceBook: priceBook,
+		menu:      menuStore,
 	}
+
+	h.orders.OnStatusUpdate = h.statusHub.Publish
+
+	return h
 }
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// subscriberBufferSize is how many pending status events a subscriber's
+// channel can hold. Once it is full, Publish drops that event for the
+// subscriber after slowSubscriberTimeout; the subscriber itself stays
+// subscribed until it unsubscribes.
+const subscriberBufferSize = 4
+
+// slowSubscriberTimeout bounds how long a broadcast will wait for a
+// subscriber's channel to have room before giving up on it.
+const slowSubscriberTimeout = 2 * time.Second
+
+// heartbeatInterval is how often an SSE comment is sent to keep idle
+// connections (and any intermediate proxies) alive.
+const heartbeatInterval = 15 * time.Second
+
+// StatusEvent is published to an order's subscribers whenever its status changes.
+type StatusEvent struct {
+	OrderID string `json:"orderId"`
+	Status  string `json:"status"`
+}
+
+// StatusHub fans out order status changes to any customer currently watching
+// the tracking page for that order, over Server-Sent Events.
+type StatusHub struct {
+	mu          sync.RWMutex
+	subscribers map[string][]chan StatusEvent
+}
+
+func NewStatusHub() *StatusHub {
+	return &StatusHub{
+		subscribers: make(map[string][]chan StatusEvent),
+	}
+}
+
+// Subscribe registers a new listener for an order's status changes. The
+// returned channel is removed from the hub and closed once unsubscribe is called.
+func (h *StatusHub) Subscribe(orderID string) (ch chan StatusEvent, unsubscribe func()) {
+	ch = make(chan StatusEvent, subscriberBufferSize)
+
+	h.mu.Lock()
+	h.subscribers[orderID] = append(h.subscribers[orderID], ch)
+	h.mu.Unlock()
+
+	unsubscribe = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		subs := h.subscribers[orderID]
+		for i, sub := range subs {
+			if sub == ch {
+				h.subscribers[orderID] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		if len(h.subscribers[orderID]) == 0 {
+			delete(h.subscribers, orderID)
+		}
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// Publish sends a status event to every subscriber of orderID. Slow
+// subscribers that don't drain their buffer within slowSubscriberTimeout are
+// skipped rather than blocking the publisher.
+//
+// The read lock is held for the duration of the send loop (not just the
+// snapshot) so that unsubscribe cannot close a channel out from under a
+// send in progress; without that, a client disconnecting mid-publish would
+// race Publish and panic on a send to a closed channel.
+func (h *StatusHub) Publish(orderID, status string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	event := StatusEvent{OrderID: orderID, Status: status}
+	for _, ch := range h.subscribers[orderID] {
+		select {
+		case ch <- event:
+		case <-time.After(slowSubscriberTimeout):
+			// subscriber isn't keeping up, drop this update for it
+		}
+	}
+}
+
+// ServeOrderEvents streams status updates for a single order as Server-Sent
+// Events until the client disconnects.
+func (h *Handler) ServeOrderEvents(c *gin.Context) {
+	orderID := c.Param("id")
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	ch, unsubscribe := h.statusHub.Subscribe(orderID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-ch:
+			if !ok {
+				return false
+			}
+			c.SSEvent("status", event.Status)
+			return true
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
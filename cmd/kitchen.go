@@ -0,0 +1,139 @@
+package main
+
+import (
+	"errors"
+	"go-pizza-tracker/internal/models"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type KitchenData struct {
+	Title     string
+	Columns   []KitchenColumn
+	PrepItems []PrepItem
+}
+
+// KitchenColumn is one kanban column of the kitchen board, e.g. all orders
+// currently "Preparing".
+type KitchenColumn struct {
+	Status string
+	Orders []models.Order
+}
+
+// PrepItem is a batch prep line: how many of a given pizza (in total, across
+// all active orders) the kitchen needs to get started on.
+type PrepItem struct {
+	Pizza string
+	Count int
+}
+
+type SetStatusForm struct {
+	Status string `form:"status" binding:"required"`
+}
+
+func (h *Handler) ServeKitchen(c *gin.Context) {
+	orders, err := h.orders.GetActiveOrders(c.Request.Context())
+	if err != nil {
+		slog.Error("Failed to load active orders", "error", err)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	columns := make([]KitchenColumn, 0, len(models.OrderStatuses)-1)
+	for _, status := range models.OrderStatuses {
+		if status == models.OrderStatusReady {
+			continue
+		}
+		columns = append(columns, KitchenColumn{Status: status})
+	}
+
+	for _, order := range orders {
+		for i := range columns {
+			if columns[i].Status == order.Status {
+				columns[i].Orders = append(columns[i].Orders, order)
+				break
+			}
+		}
+	}
+
+	c.HTML(http.StatusOK, "kitchen.tmpl", KitchenData{
+		Title:     "Kitchen",
+		Columns:   columns,
+		PrepItems: prepByPizza(orders),
+	})
+}
+
+// prepByPizza groups every item across the given orders by pizza type, so
+// the kitchen can batch-prep rather than working order by order.
+func prepByPizza(orders []models.Order) []PrepItem {
+	counts := make(map[string]int)
+	var order []string
+	for _, o := range orders {
+		for _, item := range o.Items {
+			if _, seen := counts[item.Pizza]; !seen {
+				order = append(order, item.Pizza)
+			}
+			counts[item.Pizza]++
+		}
+	}
+
+	items := make([]PrepItem, len(order))
+	for i, pizza := range order {
+		items[i] = PrepItem{Pizza: pizza, Count: counts[pizza]}
+	}
+	return items
+}
+
+func (h *Handler) HandleAdvanceOrder(c *gin.Context) {
+	orderID := c.Param("id")
+	staffID := staffUserID(c)
+
+	status, err := h.orders.AdvanceStatus(c.Request.Context(), orderID, staffID)
+	if err != nil {
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": "order cannot be advanced further"})
+			return
+		}
+		slog.Error("Failed to advance order status", "error", err, "orderID", orderID)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": status})
+}
+
+func (h *Handler) HandleSetOrderStatus(c *gin.Context) {
+	orderID := c.Param("id")
+
+	var form SetStatusForm
+	if err := c.ShouldBind(&form); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	staffID := staffUserID(c)
+
+	if err := h.orders.SetStatus(c.Request.Context(), orderID, form.Status, staffID); err != nil {
+		if errors.Is(err, models.ErrInvalidStatusTransition) {
+			c.JSON(http.StatusConflict, gin.H{"error": "cannot skip order statuses"})
+			return
+		}
+		slog.Error("Failed to set order status", "error", err, "orderID", orderID)
+		c.String(http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": form.Status})
+}
+
+// staffUserID returns the logged-in staff member's ID for the audit trail,
+// or nil if the route somehow has no user attached.
+func staffUserID(c *gin.Context) *string {
+	user := currentUser(c)
+	if user == nil {
+		return nil
+	}
+	return &user.ID
+}
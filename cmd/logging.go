@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/teris-io/shortid"
+)
+
+type loggerContextKey struct{}
+
+// RequestLogger assigns each request a short ID, puts a *slog.Logger scoped
+// to it on both the gin and request contexts, and logs one structured line
+// per request once it completes.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		reqID := shortid.MustGenerate()
+		logger := slog.Default().With("req_id", reqID)
+
+		c.Set("logger", logger)
+		c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), loggerContextKey{}, logger))
+
+		c.Next()
+
+		logger.Info("request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"latency", time.Since(start),
+			"client_ip", c.ClientIP(),
+			"user_agent", c.Request.UserAgent(),
+		)
+	}
+}
+
+// RecoveryLogger is a replacement for gin's default recovery middleware that
+// logs the panic through the request's scoped slog.Logger instead of
+// writing straight to stderr.
+func RecoveryLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			if err := recover(); err != nil {
+				loggerFromContext(c).Error("panic recovered", "error", err)
+				c.AbortWithStatus(http.StatusInternalServerError)
+			}
+		}()
+		c.Next()
+	}
+}
+
+// loggerFromContext returns the request-scoped logger set by RequestLogger,
+// falling back to the default logger if it somehow wasn't run.
+func loggerFromContext(c *gin.Context) *slog.Logger {
+	if v, ok := c.Get("logger"); ok {
+		if logger, ok := v.(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
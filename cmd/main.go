@@ -1,10 +1,15 @@
 package main
 
 import (
+	"go-pizza-tracker/internal/menu"
 	"go-pizza-tracker/internal/models"
+	"go-pizza-tracker/internal/pricing"
 	"log/slog"
+	"net/http"
 	"os"
 
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
 	"github.com/gin-gonic/gin"
 )
 
@@ -24,11 +29,36 @@ func main() {
 
 	slog.Info("Database initialised successfully")
 
-	RegisterCustomValidators()
+	priceBook, err := pricing.LoadFromFile(cfg.PriceBookPath)
+	if err != nil {
+		slog.Error("Failed to load price book", "error", err)
+		os.Exit(1)
+	}
+
+	menuStore, err := menu.NewStore(cfg.MenuPath)
+	if err != nil {
+		slog.Error("Failed to load menu", "error", err)
+		os.Exit(1)
+	}
+
+	RegisterCustomValidators(menuStore)
+
+	h := NewHandler(dbModel, priceBook, menuStore)
 
-	h := NewHandler(dbModel)
+	router := gin.New()
+	router.Use(RequestLogger(), RecoveryLogger())
 
-	router := gin.Default()
+	store := cookie.NewStore([]byte(cfg.SessionSecret))
+	// gorilla/sessions' cookie store defaults to Secure+SameSite=None and no
+	// HttpOnly, which drops sessions entirely over plain HTTP (the browser
+	// refuses to send a Secure cookie back) and leaves it readable by XSS.
+	// Secure is only safe once the app is actually served behind TLS.
+	store.Options(sessions.Options{
+		HttpOnly: true,
+		Secure:   cfg.Production,
+		SameSite: http.SameSiteLaxMode,
+	})
+	router.Use(sessions.Sessions("pizza_session", store))
 
 	if err := loadTemplates(router); err != nil {
 		slog.Error("Failed to load templates", "error", err)
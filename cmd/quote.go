@@ -0,0 +1,87 @@
+package main
+
+import (
+	"go-pizza-tracker/internal/models"
+	"go-pizza-tracker/internal/pricing"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QuoteRequest mirrors OrderRequest's pizza fields so the live JS estimate on
+// the order form can ask for a price before the order is actually submitted.
+type QuoteRequest struct {
+	Sizes      []string `json:"size" binding:"required,min=1,dive,valid_pizza_size"`
+	PizzaTypes []string `json:"pizza" binding:"required,min=1,dive,valid_pizza_type"`
+	Crusts     []string `json:"crust"`
+	Toppings   []string `json:"topping"`
+}
+
+func (h *Handler) HandleQuote(c *gin.Context) {
+	var req QuoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	items := make([]pricing.Item, len(req.Sizes))
+	for i := range items {
+		crust := "Regular"
+		if i < len(req.Crusts) && req.Crusts[i] != "" {
+			crust = req.Crusts[i]
+		}
+
+		items[i] = pricing.Item{
+			Pizza: req.PizzaTypes[i],
+			Size:  req.Sizes[i],
+			Crust: crust,
+		}
+	}
+
+	for _, t := range req.Toppings {
+		index, topping, ok := parseIndexedTopping(t)
+		if !ok || index >= len(items) {
+			continue
+		}
+		items[index].Toppings = append(items[index].Toppings, topping)
+	}
+
+	c.JSON(http.StatusOK, h.priceBook.Quote(items))
+}
+
+// parseIndexedTopping splits the "pizzaIndex:topping" format used by the
+// toppings form field; both HandleNewOrder and HandleQuote use it.
+func parseIndexedTopping(t string) (index int, topping string, ok bool) {
+	parts := strings.SplitN(t, ":", 2)
+	if len(parts) != 2 {
+		return 0, "", false
+	}
+
+	index, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", false
+	}
+
+	return index, parts[1], true
+}
+
+// pricingItems converts already-built order items (toppings resolved,
+// defaults applied) into pricing.Item for quoting a submitted order.
+func pricingItems(items []models.OrderItem) []pricing.Item {
+	pItems := make([]pricing.Item, len(items))
+	for i, item := range items {
+		toppings := make([]string, len(item.Toppings))
+		for j, t := range item.Toppings {
+			toppings[j] = t.Topping
+		}
+		pItems[i] = pricing.Item{
+			Pizza:    item.Pizza,
+			Size:     item.Size,
+			Crust:    item.Crust,
+			Toppings: toppings,
+		}
+	}
+	return pItems
+}
@@ -3,12 +3,69 @@ package main
 import "github.com/gin-gonic/gin"
 
 func setupRotues(router *gin.Engine, h *Handler) {
+	// every route gets a best-effort look-up of the logged-in user so
+	// handlers like HandleNewOrder can personalise guest checkout too
+	router.Use(h.OptionalAuth())
+
 	// this is the root path, eventually might want to add a landing pag with a button to make a new order
 	router.GET("/", h.ServeNewOrder)
 	// this is the post request from the form of creating the order
 	router.POST("/new-order", h.HandleNewOrder)
 	// the page of monitoring the pizza order status
 	router.GET("/customer/:id", h.serveCustomer)
+	// live status updates for the page above, via Server-Sent Events
+	router.GET("/customer/:id/events", h.ServeOrderEvents)
+
+	// account creation and login
+	router.GET("/register", h.ServeRegister)
+	router.POST("/register", h.HandleRegister)
+	router.GET("/login", h.ServeLogin)
+	router.POST("/login", h.HandleLogin)
+	router.POST("/logout", h.HandleLogout)
+
+	// account routes require a logged-in session
+	account := router.Group("/account")
+	account.Use(h.AuthRequired())
+	{
+		account.GET("", h.ServeAccount)
+		account.GET("/orders", h.ServeAccountOrders)
+	}
+
+	// live price estimate for the order form's JS, before the order is submitted
+	router.POST("/api/quote", h.HandleQuote)
+
+	// kitchen dashboard is staff-only
+	kitchen := router.Group("/kitchen")
+	kitchen.Use(h.AuthRequired(), h.AdminRequired())
+	{
+		kitchen.GET("", h.ServeKitchen)
+		kitchen.POST("/orders/:id/advance", h.HandleAdvanceOrder)
+		kitchen.POST("/orders/:id/status", h.HandleSetOrderStatus)
+	}
+
+	// menu administration is staff-only
+	admin := router.Group("/admin")
+	admin.Use(h.AuthRequired(), h.AdminRequired())
+	{
+		admin.GET("/menu", h.ServeAdminMenu)
+		admin.POST("/menu/reload", h.HandleReloadMenu)
+		admin.POST("/api-keys", h.HandleCreateAPIKey)
+	}
+
+	// versioned JSON API for third-party integrations
+	v1 := router.Group("/api/v1")
+	{
+		v1.GET("/menu", h.HandleGetMenuAPI)
+		v1.GET("/orders/:id", h.HandleGetOrderAPI)
+		v1.GET("/orders/:id/status", h.HandleGetOrderStatusAPI)
+
+		v1Mutating := v1.Group("")
+		v1Mutating.Use(h.APIKeyRequired())
+		{
+			v1Mutating.POST("/orders", h.HandleCreateOrderAPI)
+			v1Mutating.PATCH("/orders/:id/status", h.HandlePatchOrderStatusAPI)
+		}
+	}
 
 	// gets the router to look in the templates/static folder for the files
 	router.Static("/static", "/templates/static")
@@ -1,34 +1,37 @@
 package main
 
 import (
-	"go-pizza-tracker/internal/models"
+	"go-pizza-tracker/internal/menu"
 	"slices"
 
 	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator"
+	"github.com/go-playground/validator/v10"
 )
 
-func RegisterCustomValidators() {
+// RegisterCustomValidators wires validation rules to the live menu, so a
+// hot-reloaded menu.yaml (see /admin/menu/reload) is honoured immediately
+// without re-registering anything.
+func RegisterCustomValidators(menuStore *menu.Store) {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		v.RegisterValidation("valid_pizza_type", createSliceValidator(models.PizzaTypes))
-		v.RegisterValidation("valid_pizza_size", createSliceValidator(models.PizzaSizes))
-		v.RegisterValidation("valid_topping", createToppingValidator())
-		v.RegisterValidation("valid_dietary_requirement", createSliceValidator(models.DietaryRequirements))
-		v.RegisterValidation("valid_allergy", createSliceValidator(models.Allergies))
-		v.RegisterValidation("valid_crust", createSliceValidator(models.PizzaCrust))
+		v.RegisterValidation("valid_pizza_type", createSliceValidator(func() []string { return menuStore.Get().PizzaTypes }))
+		v.RegisterValidation("valid_pizza_size", createSliceValidator(func() []string { return menuStore.Get().PizzaSizes }))
+		v.RegisterValidation("valid_topping", createToppingValidator(menuStore))
+		v.RegisterValidation("valid_dietary_requirement", createSliceValidator(func() []string { return menuStore.Get().DietaryRequirements }))
+		v.RegisterValidation("valid_allergy", createSliceValidator(func() []string { return menuStore.Get().Allergies }))
+		v.RegisterValidation("valid_crust", createSliceValidator(func() []string { return menuStore.Get().PizzaCrust }))
 	}
 }
 
-func createSliceValidator(allowedValues []string) validator.Func {
+func createSliceValidator(allowedValues func() []string) validator.Func {
 	return func(fl validator.FieldLevel) bool {
-		return slices.Contains(allowedValues, fl.Field().String())
+		return slices.Contains(allowedValues(), fl.Field().String())
 	}
 }
 
-func createToppingValidator() validator.Func {
+func createToppingValidator(menuStore *menu.Store) validator.Func {
 	return func(fl validator.FieldLevel) bool {
 		topping := fl.Field().String()
-		for _, toppings := range models.ToppingCategories {
+		for _, toppings := range menuStore.Get().ToppingCategories {
 			if slices.Contains(toppings, topping) {
 				return true
 			}
@@ -0,0 +1,105 @@
+// Package menu loads the pizzeria's menu (pizza types, sizes, crusts,
+// toppings, dietary info) from a YAML file, so operators can change what's
+// on offer without a recompile.
+package menu
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Menu is everything the order form and menu API need to describe what can
+// be ordered.
+type Menu struct {
+	PizzaTypes           []string            `yaml:"pizzaTypes"`
+	PizzaSizes           []string            `yaml:"pizzaSizes"`
+	PizzaCrust           []string            `yaml:"pizzaCrust"`
+	Cheeses              []string            `yaml:"cheeses"`
+	ToppingCategories    map[string][]string `yaml:"toppingCategories"`
+	PizzaDefaultToppings map[string][]string `yaml:"pizzaDefaultToppings"`
+	DietaryRequirements  []string            `yaml:"dietaryRequirements"`
+	Allergies            []string            `yaml:"allergies"`
+}
+
+// LoadFromFile reads and validates a Menu from a YAML file.
+func LoadFromFile(path string) (*Menu, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("menu: failed to read menu file: %v", err)
+	}
+
+	var m Menu
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("menu: failed to parse menu file: %v", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Validate checks that every topping listed as a default for a pizza type
+// actually exists in one of the topping categories.
+func (m *Menu) Validate() error {
+	known := make(map[string]bool)
+	for _, toppings := range m.ToppingCategories {
+		for _, topping := range toppings {
+			known[topping] = true
+		}
+	}
+
+	for pizza, toppings := range m.PizzaDefaultToppings {
+		for _, topping := range toppings {
+			if !known[topping] {
+				return fmt.Errorf("menu: default topping %q for %q is not in any topping category", topping, pizza)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Store holds the currently-loaded Menu and allows it to be hot-reloaded
+// from disk without restarting the server.
+type Store struct {
+	mu   sync.RWMutex
+	menu *Menu
+	path string
+}
+
+// NewStore loads the menu at path and returns a Store serving it.
+func NewStore(path string) (*Store, error) {
+	m, err := LoadFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{menu: m, path: path}, nil
+}
+
+// Get returns the currently-loaded menu.
+func (s *Store) Get() *Menu {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.menu
+}
+
+// Reload re-reads and re-validates the menu file, swapping it in only if
+// that succeeds so a bad edit never takes down a running server.
+func (s *Store) Reload() error {
+	m, err := LoadFromFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.menu = m
+	s.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/teris-io/shortid"
+	"gorm.io/gorm"
+)
+
+// APIKey gates the JSON REST API's mutating endpoints for a given
+// third-party integration. Only the SHA-256 hash of the key is stored, the
+// same way User.PasswordHash never stores a plaintext password, so reading
+// the database doesn't hand out a live key.
+type APIKey struct {
+	ID        string    `gorm:"primaryKey;size:14" json:"id"`
+	KeyHash   string    `gorm:"uniqueIndex;not null" json:"-"`
+	Label     string    `gorm:"not null" json:"label"`
+	Revoked   bool      `gorm:"not null;default:false" json:"revoked"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == "" {
+		k.ID = shortid.MustGenerate()
+	}
+	return nil
+}
+
+type APIKeyModel struct {
+	DB *gorm.DB
+}
+
+// hashAPIKey is a plain SHA-256, not bcrypt: unlike a user password, an API
+// key is already high-entropy random data, so it needs no per-key salt or
+// slow hashing to resist brute force, and a deterministic hash lets
+// Validate look the key up by an indexed column instead of scanning every row.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// Validate reports whether key is a known, non-revoked API key.
+func (m *APIKeyModel) Validate(key string) (bool, error) {
+	var count int64
+	err := m.DB.Model(&APIKey{}).Where("key_hash = ? AND revoked = ?", hashAPIKey(key), false).Count(&count).Error
+	return count > 0, err
+}
+
+// Create provisions a new API key for label and returns the plaintext key
+// alongside the stored record. The plaintext is only ever available here,
+// at creation time — like a password, it can't be recovered afterwards, so
+// the caller (the admin route) must hand it to the operator immediately.
+func (m *APIKeyModel) Create(label string) (plaintextKey string, apiKey *APIKey, err error) {
+	plaintextKey, err = generateAPIKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	record := APIKey{Label: label, KeyHash: hashAPIKey(plaintextKey)}
+	if err := m.DB.Create(&record).Error; err != nil {
+		return "", nil, err
+	}
+
+	return plaintextKey, &record, nil
+}
+
+// generateAPIKey returns a random, high-entropy key suitable for use as a
+// long-lived bearer credential.
+func generateAPIKey() (string, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("models: failed to generate API key: %v", err)
+	}
+	return hex.EncodeToString(raw), nil
+}
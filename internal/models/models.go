@@ -8,7 +8,9 @@ import (
 )
 
 type DBModel struct {
-	Order OrderModel
+	Order   OrderModel
+	User    UserModel
+	APIKeys APIKeyModel
 }
 
 func InitDB(dataSourceName string) (*DBModel, error) {
@@ -25,13 +27,18 @@ func InitDB(dataSourceName string) (*DBModel, error) {
 		&OrderItemTopping{},
 		&OrderItemDietaryRequirement{},
 		&OrderItemAllergy{},
+		&User{},
+		&OrderStatusHistory{},
+		&APIKey{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to migrate database: %v", err)
 	}
 
 	dbModel := &DBModel{
-		Order: OrderModel{DB: db},
+		Order:   OrderModel{DB: db},
+		User:    UserModel{DB: db},
+		APIKeys: APIKeyModel{DB: db},
 	}
 
 	return dbModel, nil
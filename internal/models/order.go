@@ -1,9 +1,12 @@
 package models
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"github.com/teris-io/shortid"
 	"gorm.io/gorm"
-	"time"
 )
 
 const (
@@ -14,93 +17,56 @@ const (
 	OrderStatusReady        = "Ready"
 )
 
-var (
-	OrderStatuses = []string{
-		OrderStatusPlaced,
-		OrderStatusPreparing,
-		OrderStatusCooking,
-		OrderStatusQualityCheck,
-		OrderStatusReady,
-	}
-	PizzaSauces = []string{
-		"Tomato Sauce", "BBQ Sauce", "Buffalo Sauce", "Garlic Oil", "Truffle Oil", "Pesto",
-	}
-	Cheeses = []string{
-		"Mozzarella", "Vegan Cheese", "Extra Cheese", "Parmesan", "Gorgonzola", "Ricotta", "Feta",
-	}
-	PizzaTypes = []string{
-		"Margherita",
-		"Pepperoni",
-		"Vegetarian",
-		"Hawaiian",
-		"BBQ Chicken",
-		"Meat Lovers",
-		"Buffalo Chicken",
-		"Supreme",
-		"Truffle Mushroom",
-		"Four Cheese",
-		"Vegan Pizza",
-		"Vegan Meat Lovers",
-		"Vegan Garden",
-		"Make Your Own",
-		"Garlic",
-	}
-	PizzaCrust = []string{"Thin", "Regular", "Deep Dish", "Cheesy Crust", "Vegan Cheesy Crust"}
-	PizzaSizes = []string{"Small", "Medium", "Large", "X-Large", "Family"}
-	// map[string][]string allows me to create a junction table
-	ToppingCategories = map[string][]string{
-		"Meats": {
-			"Pepperoni", "Sausage", "Chicken", "Bacon", "Ham", "Mince", "Anchovies",
-		},
-		"Vegan Meats": {
-			"Vegan Pepperoni", "Vegan Chicken", "Vegan Bacon", "Vegan Ham", "Vegan Mince",
-		},
-		"Vegetables": {
-			"Mushroom", "Red Onion", "White Onion", "Capsicum", "Zucchini",
-			"Olives", "Jalapenos", "Pumpkin", "Spinach", "Pineapple",
-			"Tomatoes", "Basil", "Corn", "Rocket", "Garlic Slices",
-		},
-		"Cheeses": {
-			"Mozzarella", "Extra Cheese", "Vegan Cheese", "Parmesan", "Gorgonzola", "Ricotta", "Feta",
-		},
-		"Sauces": {
-			"Tomato Sauce", "BBQ Sauce", "Buffalo Sauce", "Garlic Oil", "Truffle Oil", "Pesto",
-		},
-	}
-	PizzaDefaultToppings = map[string][]string{
-		"Margherita":        {"Tomato Sauce", "Mozzarella", "Tomatoes", "Basil"},
-		"Pepperoni":         {"Tomato Sauce", "Mozzarella", "Pepperoni"},
-		"Vegetarian":        {"Tomato Sauce", "Mozzarella", "Mushroom", "Capsicum", "Red Onion", "Olives", "Zucchini"},
-		"Hawaiian":          {"Tomato Sauce", "Mozzarella", "Ham", "Pineapple"},
-		"BBQ Chicken":       {"BBQ Sauce", "Mozzarella", "Chicken", "Red Onion", "Capsicum", "Bacon"},
-		"Meat Lovers":       {"Tomato Sauce", "Mozzarella", "Pepperoni", "Sausage", "Bacon", "Ham", "Mince"},
-		"Buffalo Chicken":   {"Buffalo Sauce", "Mozzarella", "Chicken", "Red Onion", "Jalapenos"},
-		"Supreme":           {"Tomato Sauce", "Mozzarella", "Pepperoni", "Sausage", "Mushroom", "Capsicum", "Red Onion", "Olives"},
-		"Truffle Mushroom":  {"Truffle Oil", "Mozzarella", "Mushroom", "Garlic Oil", "Rocket"},
-		"Four Cheese":       {"Tomato Sauce", "Mozzarella", "Parmesan", "Gorgonzola", "Ricotta"},
-		"Vegan Pizza":       {"Tomato Sauce", "Vegan Cheese", "White Onion", "Basil", "Capsicum", "Garlic Slices", "Corn", "Zucchini"},
-		"Vegan Meat Lovers": {"BBQ Sauce", "Vegan Cheese", "White Onion", "Vegan Ham", "Vegan Mince", "Vegan Chicken", "Vegan Bacon", "Vegan Pepperoni"},
-		"Vegan Garden":      {"Pesto", "Vegan Cheese", "Red Onion", "Mushroom", "Pumpkin", "Capsicum", "Zucchini", "Spinach", "Corn", "Basil", "Rocket"},
-		"Garlic":            {"Garlic", "Mozzarella", "Garlic Oil"},
-		"Make Your Own":     {},
-	}
-	DietaryRequirements = []string{"Vegetarian", "Vegan", "Gluten-Free", "Dairy-Free", "Nut-Free", "Halal", "Kosher"}
+// OrderStatuses is the sequence an order moves through; it's a status
+// workflow rather than a menu concern, so unlike pizza types/sizes/toppings
+// it isn't part of the configurable menu.yaml.
+var OrderStatuses = []string{
+	OrderStatusPlaced,
+	OrderStatusPreparing,
+	OrderStatusCooking,
+	OrderStatusQualityCheck,
+	OrderStatusReady,
+}
 
-	Allergies = []string{"Gluten", "Dairy", "Nuts", "Peanuts", "Shellfish", "Soy", "Eggs", "Fish", "Sesame"}
-)
+// OrderStatusHistory is an audit row recording when an order moved to a new
+// status and which staff member (if any) moved it.
+type OrderStatusHistory struct {
+	ID          string    `gorm:"primaryKey;size:14" json:"id"`
+	OrderID     string    `gorm:"index;not null" json:"orderId"`
+	Status      string    `gorm:"not null" json:"status"`
+	StaffUserID *string   `json:"staffUserId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (h *OrderStatusHistory) BeforeCreate(tx *gorm.DB) error {
+	if h.ID == "" {
+		h.ID = shortid.MustGenerate()
+	}
+	return nil
+}
 
 type OrderModel struct {
 	DB *gorm.DB
+	// OnStatusUpdate, if set, is called after a status change is persisted
+	// by setStatus so callers (e.g. the SSE hub) can react to it.
+	OnStatusUpdate func(orderID, status string)
 }
 
 type Order struct {
-	ID           string      `gorm:"primaryKey; size:14" json:"id"`
-	Status       string      `gorm:"not null" json:"status"`
-	CustomerName string      `gorm:"not null" json:"customerName"`
-	Phone        string      `gorm:"not null" json:"phone"`
-	Address      string      `gorm:"not null" json:"adress"`
-	Items        []OrderItem `gorm:"foreignKey:OrderID" json:"pizzas"`
-	CreatedAt    time.Time   `json:"createdAt"`
+	ID           string `gorm:"primaryKey; size:14" json:"id"`
+	Status       string `gorm:"not null" json:"status"`
+	CustomerName string `gorm:"not null" json:"customerName"`
+	Phone        string `gorm:"not null" json:"phone"`
+	Address      string `gorm:"not null" json:"adress"`
+	// UserID is nullable so guest checkout (no account) keeps working.
+	UserID          *string     `gorm:"index" json:"userId,omitempty"`
+	User            *User       `gorm:"foreignKey:UserID" json:"-"`
+	LoyaltyDiscount float64     `gorm:"not null;default:0" json:"loyaltyDiscount"`
+	Subtotal        float64     `gorm:"not null;default:0" json:"subtotal"`
+	Tax             float64     `gorm:"not null;default:0" json:"tax"`
+	Total           float64     `gorm:"not null;default:0" json:"total"`
+	Items           []OrderItem `gorm:"foreignKey:OrderID" json:"pizzas"`
+	CreatedAt       time.Time   `json:"createdAt"`
 }
 
 type OrderItem struct {
@@ -108,7 +74,9 @@ type OrderItem struct {
 	OrderID            string                        `gorm:"index;not null" json:"orderId"`
 	Size               string                        `gorm:"not null" json:"size"`
 	Pizza              string                        `gorm:"not null" json:"pizza"`
+	Crust              string                        `gorm:"not null;default:Regular" json:"crust"`
 	Instructions       string                        `json:"instruction"`
+	Subtotal           float64                       `gorm:"not null;default:0" json:"subtotal"`
 	DietaryRequirement []OrderItemDietaryRequirement `gorm:"foreignKey:OrderItemID" json:"dietaryRequirement"`
 	Toppings           []OrderItemTopping            `gorm:"foreignKey:OrderItemID" json:"toppings"`
 	Allergies          []OrderItemAllergy            `gorm:"foreignKey:OrderItemID" json:"allergies"`
@@ -172,14 +140,107 @@ func (a *OrderItemAllergy) BeforeCreate(tx *gorm.DB) error {
 	}
 	return nil
 }
-func (o *OrderModel) CreateOrder(order *Order) error {
-	return o.DB.Create(order).Error
+func (o *OrderModel) CreateOrder(ctx context.Context, order *Order) error {
+	return o.DB.WithContext(ctx).Create(order).Error
 }
 
-func (o *OrderModel) GetOrder(id string) (*Order, error) {
+func (o *OrderModel) GetOrder(ctx context.Context, id string) (*Order, error) {
 	var order Order
 
-	err := o.DB.
+	err := o.DB.WithContext(ctx).
 		Preload("Items.Toppings").Preload("Items.DietaryRequirement").Preload("Items.Allergies").First(&order, "id = ?", id).Error
 	return &order, err
 }
+
+// GetActiveOrders returns every order that hasn't reached OrderStatusReady
+// yet, oldest first, for the kitchen dashboard.
+func (o *OrderModel) GetActiveOrders(ctx context.Context) ([]Order, error) {
+	var orders []Order
+	err := o.DB.WithContext(ctx).
+		Preload("Items.Toppings").Preload("Items.DietaryRequirement").Preload("Items.Allergies").
+		Where("status <> ?", OrderStatusReady).
+		Order("created_at asc").
+		Find(&orders).Error
+	return orders, err
+}
+
+// ErrInvalidStatusTransition is returned when a caller tries to move an
+// order to a status that doesn't immediately follow its current one in
+// OrderStatuses (e.g. skipping from Placed straight to Ready).
+var ErrInvalidStatusTransition = errors.New("models: invalid order status transition")
+
+// AdvanceStatus moves the order to the next status in OrderStatuses,
+// recording the staff member (if any) who advanced it. It returns
+// ErrInvalidStatusTransition if the order is already Ready.
+func (o *OrderModel) AdvanceStatus(ctx context.Context, id string, staffUserID *string) (string, error) {
+	var order Order
+	if err := o.DB.WithContext(ctx).First(&order, "id = ?", id).Error; err != nil {
+		return "", err
+	}
+
+	next, err := nextOrderStatus(order.Status)
+	if err != nil {
+		return "", err
+	}
+
+	return next, o.setStatus(ctx, id, order.Status, next, staffUserID)
+}
+
+// SetStatus moves the order directly to the given status, as long as it's
+// the immediate next status in OrderStatuses, recording the staff member
+// (if any) who made the change.
+func (o *OrderModel) SetStatus(ctx context.Context, id, status string, staffUserID *string) error {
+	var order Order
+	if err := o.DB.WithContext(ctx).First(&order, "id = ?", id).Error; err != nil {
+		return err
+	}
+
+	next, err := nextOrderStatus(order.Status)
+	if err != nil || status != next {
+		return ErrInvalidStatusTransition
+	}
+
+	return o.setStatus(ctx, id, order.Status, status, staffUserID)
+}
+
+// setStatus persists the id/from -> to transition. The "AND status = from"
+// guard makes this an optimistic-concurrency check: if another request has
+// already moved the order since the caller's read, RowsAffected is 0 and we
+// return ErrInvalidStatusTransition instead of silently double-advancing it.
+func (o *OrderModel) setStatus(ctx context.Context, id, from, to string, staffUserID *string) error {
+	err := o.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&Order{}).Where("id = ? AND status = ?", id, from).Update("status", to)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInvalidStatusTransition
+		}
+
+		history := OrderStatusHistory{OrderID: id, Status: to, StaffUserID: staffUserID}
+		return tx.Create(&history).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	// Notified after commit: OnStatusUpdate can block (e.g. the SSE hub
+	// waiting on a slow subscriber) and must not hold the transaction open,
+	// and a panic here should never roll back a write that already succeeded.
+	if o.OnStatusUpdate != nil {
+		o.OnStatusUpdate(id, to)
+	}
+
+	return nil
+}
+
+// nextOrderStatus returns the status that immediately follows current in
+// OrderStatuses, or ErrInvalidStatusTransition if current is the last one.
+func nextOrderStatus(current string) (string, error) {
+	for i, status := range OrderStatuses {
+		if status == current && i+1 < len(OrderStatuses) {
+			return OrderStatuses[i+1], nil
+		}
+	}
+	return "", ErrInvalidStatusTransition
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/teris-io/shortid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("models: invalid email or password")
+	ErrDuplicateEmail     = errors.New("models: email already registered")
+)
+
+type User struct {
+	ID            string    `gorm:"primaryKey;size:14" json:"id"`
+	Email         string    `gorm:"uniqueIndex;not null" json:"email"`
+	PasswordHash  string    `gorm:"not null" json:"-"`
+	Name          string    `gorm:"not null" json:"name"`
+	Phone         string    `json:"phone"`
+	Address       string    `json:"address"`
+	IsAdmin       bool      `gorm:"not null;default:false" json:"isAdmin"`
+	LoyaltyPoints int       `gorm:"not null;default:0" json:"loyaltyPoints"`
+	Orders        []Order   `gorm:"foreignKey:UserID" json:"-"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == "" {
+		u.ID = shortid.MustGenerate()
+	}
+
+	return nil
+}
+
+type UserModel struct {
+	DB *gorm.DB
+}
+
+// Register hashes the supplied password and persists a new user. It returns
+// ErrDuplicateEmail if the email is already registered.
+func (m *UserModel) Register(user *User, password string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = string(hash)
+
+	if err := m.DB.Create(user).Error; err != nil {
+		if isDuplicateEmailError(err) {
+			return ErrDuplicateEmail
+		}
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate looks up the user by email and checks the password, returning
+// ErrInvalidCredentials if either the email is unknown or the password is wrong.
+func (m *UserModel) Authenticate(email, password string) (*User, error) {
+	var user User
+	if err := m.DB.First(&user, "email = ?", email).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	return &user, nil
+}
+
+func (m *UserModel) GetByEmail(email string) (*User, error) {
+	var user User
+	err := m.DB.First(&user, "email = ?", email).Error
+	return &user, err
+}
+
+func (m *UserModel) GetByID(id string) (*User, error) {
+	var user User
+	err := m.DB.First(&user, "id = ?", id).Error
+	return &user, err
+}
+
+// GetOrders returns the user's past orders, most recent first.
+func (m *UserModel) GetOrders(userID string) ([]Order, error) {
+	var orders []Order
+	err := m.DB.
+		Preload("Items.Toppings").Preload("Items.DietaryRequirement").Preload("Items.Allergies").
+		Where("user_id = ?", userID).
+		Order("created_at desc").
+		Find(&orders).Error
+	return orders, err
+}
+
+func isDuplicateEmailError(err error) bool {
+	return strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
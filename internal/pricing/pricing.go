@@ -0,0 +1,119 @@
+// Package pricing computes itemised quotes for pizza orders from a
+// configurable price book, so prices can be tuned without a recompile.
+package pricing
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Item is one pizza line of an order, as needed to price it.
+type Item struct {
+	Pizza    string
+	Size     string
+	Crust    string
+	Toppings []string
+}
+
+// LineItem is the priced breakdown for a single Item.
+type LineItem struct {
+	Pizza          string  `json:"pizza"`
+	Size           string  `json:"size"`
+	Crust          string  `json:"crust"`
+	BasePrice      float64 `json:"basePrice"`
+	SizeMultiplier float64 `json:"sizeMultiplier"`
+	CrustSurcharge float64 `json:"crustSurcharge"`
+	ToppingsTotal  float64 `json:"toppingsTotal"`
+	Subtotal       float64 `json:"subtotal"`
+}
+
+// Quote is the priced breakdown for a whole order.
+type Quote struct {
+	Items    []LineItem `json:"items"`
+	Subtotal float64    `json:"subtotal"`
+	Tax      float64    `json:"tax"`
+	Total    float64    `json:"total"`
+}
+
+// PriceBook holds every price and multiplier needed to quote an order.
+// It's loaded from YAML so operators can tweak prices without a recompile.
+type PriceBook struct {
+	BasePrices      map[string]float64 `yaml:"basePrices"`
+	SizeMultipliers map[string]float64 `yaml:"sizeMultipliers"`
+	CrustSurcharges map[string]float64 `yaml:"crustSurcharges"`
+	// ToppingPrices is keyed by category (matching menu.Menu.ToppingCategories)
+	// then topping name, since meats/cheeses/vegetables are priced differently.
+	ToppingPrices map[string]map[string]float64 `yaml:"toppingPrices"`
+	TaxRate       float64                       `yaml:"taxRate"`
+}
+
+// LoadFromFile reads and parses a PriceBook from a YAML file.
+func LoadFromFile(path string) (*PriceBook, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("pricing: failed to read price book: %v", err)
+	}
+
+	var book PriceBook
+	if err := yaml.Unmarshal(data, &book); err != nil {
+		return nil, fmt.Errorf("pricing: failed to parse price book: %v", err)
+	}
+
+	return &book, nil
+}
+
+// toppingPrice looks up a topping across every category, defaulting to 0 for
+// unknown toppings rather than failing the whole quote.
+func (p *PriceBook) toppingPrice(topping string) float64 {
+	for _, prices := range p.ToppingPrices {
+		if price, ok := prices[topping]; ok {
+			return price
+		}
+	}
+	return 0
+}
+
+// Quote prices every item and totals them, applying tax once to the subtotal.
+func (p *PriceBook) Quote(items []Item) Quote {
+	lines := make([]LineItem, len(items))
+	var subtotal float64
+
+	for i, item := range items {
+		base := p.BasePrices[item.Pizza]
+		multiplier := p.SizeMultipliers[item.Size]
+		if multiplier == 0 {
+			multiplier = 1
+		}
+		crustSurcharge := p.CrustSurcharges[item.Crust]
+
+		var toppingsTotal float64
+		for _, topping := range item.Toppings {
+			toppingsTotal += p.toppingPrice(topping)
+		}
+
+		lineSubtotal := base*multiplier + crustSurcharge + toppingsTotal
+
+		lines[i] = LineItem{
+			Pizza:          item.Pizza,
+			Size:           item.Size,
+			Crust:          item.Crust,
+			BasePrice:      base,
+			SizeMultiplier: multiplier,
+			CrustSurcharge: crustSurcharge,
+			ToppingsTotal:  toppingsTotal,
+			Subtotal:       lineSubtotal,
+		}
+		subtotal += lineSubtotal
+	}
+
+	tax := subtotal * p.TaxRate
+
+	return Quote{
+		Items:    lines,
+		Subtotal: subtotal,
+		Tax:      tax,
+		Total:    subtotal + tax,
+	}
+}